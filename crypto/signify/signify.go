@@ -0,0 +1,155 @@
+// Package signify implements Ed25519 signing and verification of arbitrary
+// files in the signify/minisign detached-signature format: an untrusted
+// comment line, a base64-encoded signature keyed by an 8-byte key ID, a
+// trusted comment line, and a base64-encoded signature over the message
+// signature followed by the trusted comment. It is used to sign forge-built worker release artifacts (Python
+// wheels and Go helper binaries) so operators can verify provenance before
+// deploying them against the Allora network.
+package signify
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	sigAlg           = "Ed" // signify/minisign algorithm tag for Ed25519 over the raw message
+	keyIDLen         = 8
+	privKeyBlobLen   = len(sigAlg) + keyIDLen + ed25519.PrivateKeySize
+	pubKeyBlobLen    = len(sigAlg) + keyIDLen + ed25519.PublicKeySize
+	defaultUntrusted = "signify"
+)
+
+// PrivateKey is a parsed signify/minisign secret key: an 8-byte key ID used
+// to match signatures to public keys, and the underlying Ed25519 private key.
+// The algorithm tag is checked by ParsePrivateKey and not kept.
+type PrivateKey struct {
+	KeyID [keyIDLen]byte
+	Key   ed25519.PrivateKey
+}
+
+// PublicKey is the public counterpart of PrivateKey.
+type PublicKey struct {
+	KeyID [keyIDLen]byte
+	Key   ed25519.PublicKey
+}
+
+// ParsePrivateKey decodes a base64-encoded signify secret key blob. The
+// caller passes the base64 text exactly as extracted from a signify key
+// file; this function base64-decodes it exactly once to recover the raw
+// bytes and does not decode the result a second time.
+func ParsePrivateKey(blobB64 string) (*PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(blobB64))
+	if err != nil {
+		return nil, fmt.Errorf("signify: invalid private key encoding: %w", err)
+	}
+	if len(raw) != privKeyBlobLen {
+		return nil, fmt.Errorf("signify: private key blob has length %d, want %d", len(raw), privKeyBlobLen)
+	}
+	if string(raw[:len(sigAlg)]) != sigAlg {
+		return nil, fmt.Errorf("signify: unsupported algorithm tag %q", raw[:len(sigAlg)])
+	}
+	pk := &PrivateKey{Key: ed25519.PrivateKey(raw[len(sigAlg)+keyIDLen:])}
+	copy(pk.KeyID[:], raw[len(sigAlg):len(sigAlg)+keyIDLen])
+	return pk, nil
+}
+
+// ParsePublicKey decodes a base64-encoded signify public key blob.
+func ParsePublicKey(blobB64 string) (*PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(blobB64))
+	if err != nil {
+		return nil, fmt.Errorf("signify: invalid public key encoding: %w", err)
+	}
+	if len(raw) != pubKeyBlobLen {
+		return nil, fmt.Errorf("signify: public key blob has length %d, want %d", len(raw), pubKeyBlobLen)
+	}
+	if string(raw[:len(sigAlg)]) != sigAlg {
+		return nil, fmt.Errorf("signify: unsupported algorithm tag %q", raw[:len(sigAlg)])
+	}
+	pub := &PublicKey{Key: ed25519.PublicKey(raw[len(sigAlg)+keyIDLen:])}
+	copy(pub.KeyID[:], raw[len(sigAlg):len(sigAlg)+keyIDLen])
+	return pub, nil
+}
+
+// Sign produces the standard two-comment signify output for message: an
+// untrusted comment (free-form, not authenticated), the base64 signature
+// over message, a trusted comment, and a base64 signature over the
+// concatenation of the message signature and the trusted comment (so the
+// trusted comment cannot be swapped without invalidating the file).
+func Sign(key *PrivateKey, message []byte, untrustedComment, trustedComment string) (string, error) {
+	if untrustedComment == "" {
+		untrustedComment = defaultUntrusted
+	}
+	if strings.ContainsAny(trustedComment, "\n\r") {
+		return "", errors.New("signify: trusted comment must be a single line")
+	}
+
+	sig := ed25519.Sign(key.Key, message)
+	sigBlob := append(append([]byte(sigAlg), key.KeyID[:]...), sig...)
+
+	globalMsg := append(append([]byte{}, sig...), []byte(trustedComment)...)
+	globalSig := ed25519.Sign(key.Key, globalMsg)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "untrusted comment: %s\n", untrustedComment)
+	fmt.Fprintf(&b, "%s\n", base64.StdEncoding.EncodeToString(sigBlob))
+	fmt.Fprintf(&b, "trusted comment: %s\n", trustedComment)
+	fmt.Fprintf(&b, "%s\n", base64.StdEncoding.EncodeToString(globalSig))
+	return b.String(), nil
+}
+
+// Verify checks a signify-format signature file against message. It rejects
+// signature files with a multi-line trusted comment, accepts an empty
+// untrusted comment, and splits lines on "\n" only so a stray "\r" is never
+// mistaken for a line ending.
+func Verify(pub *PublicKey, sigFile []byte, message []byte) (bool, error) {
+	lines := strings.Split(string(sigFile), "\n")
+	// Trailing newline produces one empty trailing element; drop it.
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) != 4 {
+		return false, fmt.Errorf("signify: expected 4 lines (untrusted comment, signature, trusted comment, global signature), got %d", len(lines))
+	}
+	untrustedLine, sigLine, trustedLine, globalSigLine := lines[0], lines[1], lines[2], lines[3]
+
+	if !strings.HasPrefix(untrustedLine, "untrusted comment:") {
+		return false, errors.New("signify: missing untrusted comment header")
+	}
+	if !strings.HasPrefix(trustedLine, "trusted comment:") {
+		return false, errors.New("signify: missing trusted comment header")
+	}
+	trustedComment := strings.TrimPrefix(trustedLine, "trusted comment:")
+	trustedComment = strings.TrimPrefix(trustedComment, " ")
+
+	sigBlob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(sigLine))
+	if err != nil {
+		return false, fmt.Errorf("signify: invalid signature encoding: %w", err)
+	}
+	if len(sigBlob) != len(sigAlg)+keyIDLen+ed25519.SignatureSize {
+		return false, fmt.Errorf("signify: signature blob has length %d, want %d", len(sigBlob), len(sigAlg)+keyIDLen+ed25519.SignatureSize)
+	}
+	if string(sigBlob[:len(sigAlg)]) != sigAlg {
+		return false, fmt.Errorf("signify: unsupported algorithm tag %q", sigBlob[:len(sigAlg)])
+	}
+	var keyID [keyIDLen]byte
+	copy(keyID[:], sigBlob[len(sigAlg):len(sigAlg)+keyIDLen])
+	if keyID != pub.KeyID {
+		return false, fmt.Errorf("signify: signature key ID %x does not match public key ID %x", keyID, pub.KeyID)
+	}
+	sig := sigBlob[len(sigAlg)+keyIDLen:]
+
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(globalSigLine))
+	if err != nil {
+		return false, fmt.Errorf("signify: invalid global signature encoding: %w", err)
+	}
+
+	if !ed25519.Verify(pub.Key, message, sig) {
+		return false, nil
+	}
+	globalMsg := append(append([]byte{}, sig...), []byte(trustedComment)...)
+	return ed25519.Verify(pub.Key, globalMsg, globalSig), nil
+}
@@ -0,0 +1,142 @@
+package signify
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func generateTestKeys(t *testing.T) (*PrivateKey, *PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	var keyID [keyIDLen]byte
+	copy(keyID[:], "testkey1")
+
+	privBlob := append(append([]byte(sigAlg), keyID[:]...), priv...)
+	pubBlob := append(append([]byte(sigAlg), keyID[:]...), pub...)
+
+	privKey, err := ParsePrivateKey(base64.StdEncoding.EncodeToString(privBlob))
+	if err != nil {
+		t.Fatalf("ParsePrivateKey: %v", err)
+	}
+	pubKey, err := ParsePublicKey(base64.StdEncoding.EncodeToString(pubBlob))
+	if err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+	return privKey, pubKey
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	priv, pub := generateTestKeys(t)
+	message := []byte("worker-release-artifact-bytes")
+
+	sigFile, err := Sign(priv, message, "release v1", "built by forge")
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	ok, err := Verify(pub, []byte(sigFile), message)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected signature to verify")
+	}
+
+	if ok, _ := Verify(pub, []byte(sigFile), []byte("tampered")); ok {
+		t.Fatal("expected verification to fail for a tampered message")
+	}
+}
+
+func TestSignDefaultsUntrustedComment(t *testing.T) {
+	priv, pub := generateTestKeys(t)
+	message := []byte("payload")
+
+	sigFile, err := Sign(priv, message, "", "trusted")
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if !strings.HasPrefix(sigFile, "untrusted comment: "+defaultUntrusted) {
+		t.Fatalf("expected default untrusted comment, got: %q", sigFile)
+	}
+
+	ok, err := Verify(pub, []byte(sigFile), message)
+	if err != nil || !ok {
+		t.Fatalf("expected signature with empty untrusted comment to verify, ok=%v err=%v", ok, err)
+	}
+}
+
+func TestSignRejectsMultiLineTrustedComment(t *testing.T) {
+	priv, _ := generateTestKeys(t)
+	if _, err := Sign(priv, []byte("payload"), "", "line one\nline two"); err == nil {
+		t.Fatal("expected Sign to reject a multi-line trusted comment")
+	}
+}
+
+func TestVerifyRejectsMultiLineTrustedComment(t *testing.T) {
+	priv, pub := generateTestKeys(t)
+	message := []byte("payload")
+	sigFile, err := Sign(priv, message, "", "trusted")
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	lines := strings.SplitN(sigFile, "\n", 4)
+	tampered := lines[0] + "\n" + lines[1] + "\n" + "trusted comment: line one\nline two\n" + lines[3]
+
+	if _, err := Verify(pub, []byte(tampered), message); err == nil {
+		t.Fatal("expected Verify to reject a signature file with a multi-line trusted comment")
+	}
+}
+
+func TestVerifyDoesNotTreatCarriageReturnAsNewline(t *testing.T) {
+	priv, pub := generateTestKeys(t)
+	message := []byte("payload")
+
+	// Build the signature file by hand (bypassing Sign's stricter trusted
+	// comment check) so the trusted comment carries a trailing \r. Verify
+	// must treat that \r as part of the line's content, not as a line
+	// break, and so must split its 4 logical lines on "\n" only.
+	trustedComment := "trusted\r"
+	sig := ed25519.Sign(priv.Key, message)
+	sigBlob := append(append([]byte(sigAlg), priv.KeyID[:]...), sig...)
+	globalMsg := append(append([]byte{}, sig...), []byte(trustedComment)...)
+	globalSig := ed25519.Sign(priv.Key, globalMsg)
+
+	sigFile := "untrusted comment: test\n" +
+		base64.StdEncoding.EncodeToString(sigBlob) + "\n" +
+		"trusted comment: " + trustedComment + "\n" +
+		base64.StdEncoding.EncodeToString(globalSig) + "\n"
+
+	ok, err := Verify(pub, []byte(sigFile), message)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a trusted comment containing \\r to verify as part of the line, not as a line break")
+	}
+}
+
+func TestParsePrivateKeyDecodesOnlyOnce(t *testing.T) {
+	priv, _ := generateTestKeys(t)
+
+	// Re-encoding the already-parsed key's raw bytes and parsing again must
+	// round-trip to the same key material; ParsePrivateKey must not
+	// base64-decode its input a second time.
+	raw := append(append([]byte(sigAlg), priv.KeyID[:]...), priv.Key...)
+	again, err := ParsePrivateKey(base64.StdEncoding.EncodeToString(raw))
+	if err != nil {
+		t.Fatalf("ParsePrivateKey: %v", err)
+	}
+	if !again.Key.Equal(priv.Key) {
+		t.Fatal("expected re-parsed private key to match the original")
+	}
+	if again.KeyID != priv.KeyID {
+		t.Fatal("expected re-parsed key ID to match the original")
+	}
+}
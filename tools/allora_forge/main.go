@@ -0,0 +1,122 @@
+// Command allora-forge is the operator-facing CLI for the forge builder
+// kit's release tooling. Today it only wires up signify-format release
+// signing; the Cosmos keyring payload signer lives in
+// tools/sign_worker_bundle.
+//
+// Usage:
+//
+//	allora-forge sign-release --priv-key BASE64 --trusted-comment "..." [--out FILE.sig] FILE
+//	allora-forge verify-release --pub-key BASE64 --sig FILE.sig FILE
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/MISHHF93/allora-forge-builder-kit/crypto/signify"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "sign-release":
+		runSignRelease(os.Args[2:])
+	case "verify-release":
+		runVerifyRelease(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: allora-forge <sign-release|verify-release> [flags] FILE")
+}
+
+func runSignRelease(args []string) {
+	fs := flag.NewFlagSet("sign-release", flag.ExitOnError)
+	privKeyB64 := fs.String("priv-key", os.Getenv("ALLORA_FORGE_SIGNING_KEY"), "base64-encoded signify private key blob")
+	untrustedComment := fs.String("untrusted-comment", "", "free-form, unauthenticated comment")
+	trustedComment := fs.String("trusted-comment", "", "single-line comment covered by the signature")
+	out := fs.String("out", "", "signature output path (default: FILE.sig)")
+	if err := fs.Parse(args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+	if fs.NArg() != 1 {
+		log.Fatalf("expected exactly one FILE argument")
+	}
+	if *privKeyB64 == "" {
+		log.Fatalf("--priv-key (or ALLORA_FORGE_SIGNING_KEY) is required")
+	}
+	path := fs.Arg(0)
+
+	key, err := signify.ParsePrivateKey(*privKeyB64)
+	if err != nil {
+		log.Fatalf("failed to load private key: %v", err)
+	}
+	msg, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", path, err)
+	}
+	sigText, err := signify.Sign(key, msg, *untrustedComment, *trustedComment)
+	if err != nil {
+		log.Fatalf("failed to sign %s: %v", path, err)
+	}
+
+	sigPath := *out
+	if sigPath == "" {
+		sigPath = path + ".sig"
+	}
+	if err := ioutil.WriteFile(sigPath, []byte(sigText), 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", sigPath, err)
+	}
+	fmt.Println(sigPath)
+}
+
+func runVerifyRelease(args []string) {
+	fs := flag.NewFlagSet("verify-release", flag.ExitOnError)
+	pubKeyB64 := fs.String("pub-key", os.Getenv("ALLORA_FORGE_VERIFY_KEY"), "base64-encoded signify public key blob")
+	sigPath := fs.String("sig", "", "signature file path (default: FILE.sig)")
+	if err := fs.Parse(args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+	if fs.NArg() != 1 {
+		log.Fatalf("expected exactly one FILE argument")
+	}
+	if *pubKeyB64 == "" {
+		log.Fatalf("--pub-key (or ALLORA_FORGE_VERIFY_KEY) is required")
+	}
+	path := fs.Arg(0)
+	if *sigPath == "" {
+		*sigPath = path + ".sig"
+	}
+
+	pub, err := signify.ParsePublicKey(*pubKeyB64)
+	if err != nil {
+		log.Fatalf("failed to load public key: %v", err)
+	}
+	msg, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", path, err)
+	}
+	sigFile, err := ioutil.ReadFile(*sigPath)
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", *sigPath, err)
+	}
+
+	ok, err := signify.Verify(pub, sigFile, msg)
+	if err != nil {
+		log.Fatalf("failed to verify %s: %v", path, err)
+	}
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s: signature invalid\n", path)
+		os.Exit(1)
+	}
+	fmt.Printf("%s: signature valid\n", path)
+}
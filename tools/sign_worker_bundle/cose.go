@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSE algorithm identifiers (RFC 8152 / IANA COSE Algorithms registry).
+const (
+	coseAlgES256K = -47 // ECDSA w/ secp256k1, matches Allora account keys
+	coseAlgEdDSA  = -8  // EdDSA (Ed25519)
+	coseHeaderAlg = 1
+	coseHeaderKid = 4
+)
+
+// coseAlgForKeyType maps a Cosmos SDK key type string (as reported by
+// pubKey.Type()) to its COSE algorithm identifier.
+func coseAlgForKeyType(keyType string) (int, error) {
+	switch keyType {
+	case "secp256k1":
+		return coseAlgES256K, nil
+	case "ed25519":
+		return coseAlgEdDSA, nil
+	default:
+		return 0, fmt.Errorf("no COSE algorithm mapping for key type %q", keyType)
+	}
+}
+
+var cborEncMode = func() cbor.EncMode {
+	mode, err := cbor.CanonicalEncOptions().EncMode()
+	if err != nil {
+		// CanonicalEncOptions() is a fixed, known-good option set; the only
+		// way EncMode() fails is a programmer error in the options above.
+		panic(err)
+	}
+	return mode
+}()
+
+// signCOSESign1 produces a base64-encoded COSE_Sign1 structure (RFC 8152
+// §4.2) over payload: a protected header carrying alg, an unprotected
+// header carrying kid, the payload itself, and a signature computed over
+// the canonical CBOR encoding of the Sig_structure
+// ["Signature1", protected, external_aad, payload]. It targets
+// resource-constrained edge workers that need a compact binary envelope
+// rather than the JSON-based JWS format.
+func signCOSESign1(kr keyring.Keyring, keyName string, payload []byte) {
+	record, err := kr.Key(keyName)
+	if err != nil {
+		log.Fatalf("failed to load key %q: %v", keyName, err)
+	}
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		log.Fatalf("failed to read public key for %q: %v", keyName, err)
+	}
+	alg, err := coseAlgForKeyType(pubKey.Type())
+	if err != nil {
+		log.Fatalf("failed to pick COSE algorithm: %v", err)
+	}
+
+	protected, err := cborEncMode.Marshal(map[int]interface{}{
+		coseHeaderAlg: alg,
+	})
+	if err != nil {
+		log.Fatalf("failed to encode cose protected header: %v", err)
+	}
+	unprotected := map[int]interface{}{
+		coseHeaderKid: []byte(keyName),
+	}
+
+	sigStructure := []interface{}{"Signature1", protected, []byte{}, payload}
+	toBeSigned, err := cborEncMode.Marshal(sigStructure)
+	if err != nil {
+		log.Fatalf("failed to encode cose Sig_structure: %v", err)
+	}
+
+	sig, _, err := kr.Sign(keyName, toBeSigned)
+	if err != nil {
+		log.Fatalf("failed to sign: %v", err)
+	}
+
+	sign1 := []interface{}{protected, unprotected, payload, sig}
+	out, err := cborEncMode.Marshal(sign1)
+	if err != nil {
+		log.Fatalf("failed to encode COSE_Sign1: %v", err)
+	}
+	fmt.Println(base64.StdEncoding.EncodeToString(out))
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+)
+
+// jwsHeader is the subset of RFC 7515 protected header fields this tool
+// populates: ES256K to match Allora's secp256k1 account keys, kid naming the
+// keyring key that signed the payload, and (for detached payloads) the
+// RFC 7797 b64/crit pair that tells verifiers the payload was not
+// base64url-encoded into the envelope.
+type jwsHeader struct {
+	Alg  string   `json:"alg"`
+	Kid  string   `json:"kid"`
+	B64  *bool    `json:"b64,omitempty"`
+	Crit []string `json:"crit,omitempty"`
+}
+
+// signJWS emits a compact JWS (RFC 7515) over payload using ES256K. With
+// detached set, it uses the RFC 7797 unencoded-payload option so large
+// inference payloads can be sent once on the wire and signed separately,
+// rather than duplicated inside the base64url payload segment.
+func signJWS(kr keyring.Keyring, keyName string, payload []byte, detached bool) {
+	header := jwsHeader{Alg: "ES256K", Kid: keyName}
+	if detached {
+		f := false
+		header.B64 = &f
+		header.Crit = []string{"b64"}
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		log.Fatalf("failed to marshal jws header: %v", err)
+	}
+	protected := base64URLEncode(headerJSON)
+
+	var payloadSegment string
+	var signingInput []byte
+	if detached {
+		// RFC 7797: signing input is protected || '.' || payload, with the
+		// raw payload bytes standing in for the usual base64url segment.
+		signingInput = append([]byte(protected+"."), payload...)
+	} else {
+		payloadSegment = base64URLEncode(payload)
+		signingInput = []byte(protected + "." + payloadSegment)
+	}
+
+	sig, _, err := kr.Sign(keyName, signingInput)
+	if err != nil {
+		log.Fatalf("failed to sign: %v", err)
+	}
+
+	fmt.Printf("%s.%s.%s\n", protected, payloadSegment, base64URLEncode(sig))
+}
+
+func base64URLEncode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -1,28 +1,259 @@
+// Command sign_worker_bundle signs JSON inference/worker payloads with an
+// Allora keyring key so Python callers (the forge builder, worker harness,
+// etc.) can attach a verifiable signature without shelling out to the full
+// allorad CLI.
+//
+// Usage:
+//
+//	sign_worker_bundle [--keyring-backend os|file|test] [--keyring-dir DIR] --key-name NAME [--format raw|jws|cose-sign1] [--detached-payload] < payload.json
+//	sign_worker_bundle verify --pub-key BASE64 --signature BASE64 < payload.json
+//
+// The verify subcommand only round-trips --format raw signatures; --format
+// jws and --format cose-sign1 envelopes are meant to be checked with a
+// standard JOSE or COSE library instead.
 package main
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types/bech32"
 )
 
-// This is a placeholder helper to demonstrate wiring a signing step from Python.
-// It reads a JSON blob from stdin and echoes a fake base64 signature to stdout.
-// Replace with real signing using the Allora keyring if Go toolchain and SDK are available.
+// alloBech32Prefix is the HRP Allora uses for account addresses.
+const alloBech32Prefix = "allo"
+
+type signOutput struct {
+	Signature string `json:"signature"`
+	PubKey    string `json:"pub_key"`
+	Address   string `json:"address"`
+}
 
 func main() {
-	b, err := ioutil.ReadAll(os.Stdin)
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		runVerify(os.Args[2:])
+		return
+	}
+	runSign(os.Args[1:])
+}
+
+func runSign(args []string) {
+	fs := flag.NewFlagSet("sign", flag.ExitOnError)
+	backend := fs.String("keyring-backend", envOr("ALLORA_KEYRING_BACKEND", keyring.BackendOS), "keyring backend: os, file, or test")
+	keyringDir := fs.String("keyring-dir", envOr("ALLORA_KEYRING_DIR", defaultKeyringDir()), "directory for the file/test keyring backend")
+	keyName := fs.String("key-name", os.Getenv("ALLORA_KEY_NAME"), "name of the key in the keyring to sign with")
+	passphrase := fs.String("keyring-passphrase", os.Getenv("ALLORA_KEYRING_PASSPHRASE"), "passphrase to unlock the file keyring backend, for non-interactive use")
+	format := fs.String("format", "raw", "output format: raw, jws, or cose-sign1")
+	detachedPayload := fs.Bool("detached-payload", false, "for --format jws, omit the payload from the envelope (RFC 7797 b64:false)")
+	if err := fs.Parse(args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+	if *keyName == "" {
+		log.Fatalf("--key-name (or ALLORA_KEY_NAME) is required")
+	}
+
+	// Read the full payload off stdin before touching the keyring: the file
+	// and os backends read a passphrase from their own input reader, and
+	// that reader must not be the same stdin the payload was just drained
+	// from (it would see only EOF).
+	payload, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
 		log.Fatalf("failed to read stdin: %v", err)
 	}
-	var v map[string]interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
+	signBytes, err := canonicalJSON(payload)
+	if err != nil {
 		log.Fatalf("invalid json: %v", err)
 	}
-	// Fake signature bytes derived from SHA-like truncation (not secure)
-	fake := base64.StdEncoding.EncodeToString([]byte("FAKE_SIGNATURE"))
-	fmt.Print(fake)
+
+	kr, closeInput, err := openKeyring(*backend, *keyringDir, *passphrase)
+	if err != nil {
+		log.Fatalf("failed to open keyring: %v", err)
+	}
+	defer closeInput()
+	if _, err := kr.Key(*keyName); err != nil {
+		log.Fatalf("failed to load key %q: %v", *keyName, err)
+	}
+
+	switch *format {
+	case "raw":
+		signRaw(kr, *keyName, signBytes)
+	case "jws":
+		signJWS(kr, *keyName, signBytes, *detachedPayload)
+	case "cose-sign1":
+		signCOSESign1(kr, *keyName, signBytes)
+	default:
+		log.Fatalf("unknown --format %q (want raw, jws, or cose-sign1)", *format)
+	}
+}
+
+func signRaw(kr keyring.Keyring, keyName string, signBytes []byte) {
+	sig, pubKey, err := kr.Sign(keyName, signBytes)
+	if err != nil {
+		log.Fatalf("failed to sign: %v", err)
+	}
+	address, err := bech32Address(pubKey)
+	if err != nil {
+		log.Fatalf("failed to derive address: %v", err)
+	}
+
+	out := signOutput{
+		Signature: base64.StdEncoding.EncodeToString(sig),
+		PubKey:    base64.StdEncoding.EncodeToString(pubKey.Bytes()),
+		Address:   address,
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(out); err != nil {
+		log.Fatalf("failed to encode output: %v", err)
+	}
+}
+
+func runVerify(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	pubKeyB64 := fs.String("pub-key", "", "base64-encoded secp256k1 public key")
+	sigB64 := fs.String("signature", "", "base64-encoded signature to verify")
+	if err := fs.Parse(args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+	if *pubKeyB64 == "" || *sigB64 == "" {
+		log.Fatalf("--pub-key and --signature are required")
+	}
+
+	payload, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatalf("failed to read stdin: %v", err)
+	}
+	signBytes, err := canonicalJSON(payload)
+	if err != nil {
+		log.Fatalf("invalid json: %v", err)
+	}
+
+	pubKeyBytes, err := base64.StdEncoding.DecodeString(*pubKeyB64)
+	if err != nil {
+		log.Fatalf("invalid pub key: %v", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(*sigB64)
+	if err != nil {
+		log.Fatalf("invalid signature: %v", err)
+	}
+
+	pubKey := &secp256k1.PubKey{Key: pubKeyBytes}
+	if !pubKey.VerifySignature(signBytes, sig) {
+		fmt.Fprintln(os.Stderr, "signature invalid")
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stdout, "signature valid")
+}
+
+// canonicalJSON re-marshals payload with object keys sorted and no
+// insignificant whitespace, so the same logical payload always produces the
+// same sign bytes regardless of how the caller formatted it. encoding/json
+// already marshals map keys in sorted order, so round-tripping through
+// map[string]interface{} is sufficient - except that the default decoder
+// parses numbers into float64, which silently loses precision for integers
+// beyond 2^53 (nanosecond timestamps, nonces, block heights). Decoding with
+// UseNumber() instead preserves each number as its original json.Number
+// literal, which encoding/json re-emits verbatim, so a Python canonicalizer
+// implementing the same "sorted keys, no whitespace" spec produces the
+// identical sign bytes.
+func canonicalJSON(payload []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// openKeyring opens the Cosmos SDK keyring and returns a closer to release
+// the passphrase input reader once signing is done.
+//
+// stdin is unavailable here because the caller already consumed it to read
+// the payload to sign, so a non-empty passphrase is read from a flag/env
+// var. The test backend never touches its reader and the os backend only
+// falls back to it when the OS credential store is unavailable, so the
+// controlling terminal is opened lazily - only the first time keyring.New
+// actually tries to read from it - rather than up front, which would make
+// every headless `--keyring-backend test` invocation fail on "no
+// controlling terminal available" even though it never needed one.
+func openKeyring(backend, dir, passphrase string) (keyring.Keyring, func() error, error) {
+	input := newKeyringInput(passphrase)
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+	kr, err := keyring.New("allora-forge", backend, dir, input, cdc)
+	if err != nil {
+		input.Close()
+		return nil, nil, err
+	}
+	return kr, input.Close, nil
+}
+
+// lazyTTYInput is an io.Reader that, absent a configured passphrase, only
+// opens /dev/tty the first time something actually reads from it.
+type lazyTTYInput struct {
+	passphrase string
+	r          io.Reader
+	tty        *os.File
+	opened     bool
+}
+
+func newKeyringInput(passphrase string) *lazyTTYInput {
+	return &lazyTTYInput{passphrase: passphrase}
+}
+
+func (l *lazyTTYInput) Read(p []byte) (int, error) {
+	if l.r == nil {
+		if l.passphrase != "" {
+			l.r = strings.NewReader(l.passphrase + "\n")
+		} else {
+			tty, err := os.Open("/dev/tty")
+			if err != nil {
+				return 0, fmt.Errorf("no --keyring-passphrase/ALLORA_KEYRING_PASSPHRASE set and no controlling terminal available to prompt for one: %w", err)
+			}
+			l.tty = tty
+			l.opened = true
+			l.r = tty
+		}
+	}
+	return l.r.Read(p)
+}
+
+func (l *lazyTTYInput) Close() error {
+	if l.opened {
+		return l.tty.Close()
+	}
+	return nil
+}
+
+func bech32Address(pubKey cryptotypes.PubKey) (string, error) {
+	return sdktypes.ConvertAndEncode(alloBech32Prefix, pubKey.Address().Bytes())
+}
+
+func defaultKeyringDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".allora-forge"
+	}
+	return home + "/.allora-forge"
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
 }
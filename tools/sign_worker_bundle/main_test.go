@@ -0,0 +1,110 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+)
+
+func TestCanonicalJSONSortsKeysAndStripsWhitespace(t *testing.T) {
+	got, err := canonicalJSON([]byte(`{  "b": 2, "a": 1 }`))
+	if err != nil {
+		t.Fatalf("canonicalJSON: %v", err)
+	}
+	if want := `{"a":1,"b":2}`; string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestCanonicalJSONPreservesBigIntegerPrecision(t *testing.T) {
+	in := []byte(`{"timestamp_unix_nano":1753875600123456789}`)
+	got, err := canonicalJSON(in)
+	if err != nil {
+		t.Fatalf("canonicalJSON: %v", err)
+	}
+	if want := `{"timestamp_unix_nano":1753875600123456789}`; string(got) != want {
+		t.Fatalf("got %q, want %q (precision lost)", got, want)
+	}
+}
+
+func TestCanonicalJSONRejectsInvalidJSON(t *testing.T) {
+	if _, err := canonicalJSON([]byte(`not json`)); err == nil {
+		t.Fatal("expected an error for invalid json")
+	}
+}
+
+func TestCoseAlgForKeyType(t *testing.T) {
+	tests := []struct {
+		keyType string
+		want    int
+		wantErr bool
+	}{
+		{keyType: "secp256k1", want: coseAlgES256K},
+		{keyType: "ed25519", want: coseAlgEdDSA},
+		{keyType: "bn254", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := coseAlgForKeyType(tt.keyType)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("coseAlgForKeyType(%q): expected an error", tt.keyType)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("coseAlgForKeyType(%q): %v", tt.keyType, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("coseAlgForKeyType(%q) = %d, want %d", tt.keyType, got, tt.want)
+		}
+	}
+}
+
+func newTestKeyring(t *testing.T) keyring.Keyring {
+	t.Helper()
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+	kr, err := keyring.New("allora-forge-test", keyring.BackendTest, t.TempDir(), nil, cdc)
+	if err != nil {
+		t.Fatalf("failed to open test keyring: %v", err)
+	}
+	if _, _, err := kr.NewMnemonic("signer", keyring.English, hd.CreateHDPath(118, 0, 0).String(), "", hd.Secp256k1); err != nil {
+		t.Fatalf("failed to create test key: %v", err)
+	}
+	return kr
+}
+
+func TestSignRawRoundTripsWithBackendTest(t *testing.T) {
+	kr := newTestKeyring(t)
+
+	signBytes, err := canonicalJSON([]byte(`{"inference": 42}`))
+	if err != nil {
+		t.Fatalf("canonicalJSON: %v", err)
+	}
+
+	sig, pubKey, err := kr.Sign("signer", signBytes)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	if !pubKey.VerifySignature(signBytes, sig) {
+		t.Fatal("expected signature to verify against the signed bytes")
+	}
+	if pubKey.VerifySignature([]byte(`{"inference": 43}`), sig) {
+		t.Fatal("expected verification to fail for a different payload")
+	}
+
+	// Verification must use the secp256k1.PubKey wrapper that runVerify
+	// constructs from the raw bytes, not just the keyring's own record.
+	rehydrated := &secp256k1.PubKey{Key: pubKey.Bytes()}
+	if !rehydrated.VerifySignature(signBytes, sig) {
+		t.Fatal("expected signature to verify after round-tripping the public key through its raw bytes")
+	}
+}